@@ -38,6 +38,11 @@ type Options struct {
 
 	// Mapper, if provided, will be used to map GroupVersionKinds to Resources
 	Mapper meta.RESTMapper
+
+	// UseProtobuf, if true, will cause built-in Kubernetes types to be sent to and received
+	// from the API server as protobuf instead of JSON.  Types which do not support protobuf
+	// (e.g. the types of CRDs) and unstructured objects will still use JSON.
+	UseProtobuf bool
 }
 
 // New returns a new Client using the provided config and Options.
@@ -72,6 +77,7 @@ func New(config *rest.Config, options Options) (Client, error) {
 				scheme:         options.Scheme,
 				mapper:         options.Mapper,
 				codecs:         serializer.NewCodecFactory(options.Scheme),
+				useProtobuf:    options.UseProtobuf,
 				resourceByType: make(map[reflect.Type]*resourceMeta),
 			},
 			paramCodec: runtime.NewParameterCodec(options.Scheme),
@@ -121,6 +127,15 @@ func (c *client) Delete(ctx context.Context, obj runtime.Object, opts ...DeleteO
 	return c.typedClient.Delete(ctx, obj, opts...)
 }
 
+// Patch implements client.Client
+func (c *client) Patch(ctx context.Context, obj runtime.Object, patch Patch) error {
+	_, ok := obj.(*unstructured.Unstructured)
+	if ok {
+		return c.unstructuredClient.Patch(ctx, obj, patch)
+	}
+	return c.typedClient.Patch(ctx, obj, patch)
+}
+
 // Get implements client.Client
 func (c *client) Get(ctx context.Context, key ObjectKey, obj runtime.Object) error {
 	_, ok := obj.(*unstructured.Unstructured)
@@ -30,6 +30,18 @@ import (
 	"k8s.io/client-go/rest"
 )
 
+// protobufContentType is the content type used by the API server for protobuf.
+const protobufContentType = "application/vnd.kubernetes.protobuf"
+
+// isProtobufType returns true if obj can be serialized as protobuf.  Only the
+// generated types of built-in Kubernetes APIs support this.
+func isProtobufType(obj runtime.Object) bool {
+	_, ok := obj.(interface {
+		ProtoMessage()
+	})
+	return ok
+}
+
 // clientCache creates and caches rest clients and metadata for Kubernetes types
 type clientCache struct {
 	// config is the rest.Config to talk to an apiserver
@@ -44,6 +56,9 @@ type clientCache struct {
 	// codecs are used to create a REST client for a gvk
 	codecs serializer.CodecFactory
 
+	// useProtobuf enables protobuf for types which support it
+	useProtobuf bool
+
 	// resourceByType caches type metadata
 	resourceByType map[reflect.Type]*resourceMeta
 	mu             sync.RWMutex
@@ -62,7 +77,16 @@ func (c *clientCache) newResource(obj runtime.Object) (*resourceMeta, error) {
 		gvk.Kind = gvk.Kind[:len(gvk.Kind)-4]
 	}
 
-	client, err := apiutil.RESTClientForGVK(gvk, c.config, c.codecs)
+	config := c.config
+	if c.useProtobuf && isProtobufType(obj) {
+		// A shallow copy is enough, RESTClientForGVK copies the config again
+		protoConfig := *c.config
+		protoConfig.ContentType = protobufContentType
+		protoConfig.AcceptContentTypes = protobufContentType + "," + runtime.ContentTypeJSON
+		config = &protoConfig
+	}
+
+	client, err := apiutil.RESTClientForGVK(gvk, config, c.codecs)
 	if err != nil {
 		return nil, err
 	}
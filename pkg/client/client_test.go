@@ -19,6 +19,10 @@ package client_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"sync/atomic"
 
 	. "github.com/onsi/ginkgo"
@@ -27,11 +31,15 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 	kscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 )
 
 const serverSideTimeoutSeconds = 10
@@ -181,6 +189,47 @@ var _ = Describe("Client", func() {
 				close(done)
 			})
 
+			It("should create a new object from a go struct using protobuf", func(done Done) {
+				cl, err := client.New(cfg, client.Options{UseProtobuf: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("creating the object")
+				err = cl.Create(context.TODO(), dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				actual, err := clientset.AppsV1().Deployments(ns).Get(dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual).NotTo(BeNil())
+
+				By("writing the result back to the go struct")
+				Expect(dep).To(Equal(actual))
+
+				close(done)
+			})
+
+			It("should populate the generated name from the server response", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("creating the object with a GenerateName")
+				dep.Name = ""
+				dep.GenerateName = "deployment-generated-"
+				err = cl.Create(context.TODO(), dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("writing the generated name and resourceVersion back to the go struct")
+				Expect(dep.Name).To(HavePrefix("deployment-generated-"))
+				Expect(dep.ResourceVersion).NotTo(BeEmpty())
+
+				actual, err := clientset.AppsV1().Deployments(ns).Get(dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(dep).To(Equal(actual))
+
+				close(done)
+			})
+
 			It("should create a new object non-namespace object from a go struct", func(done Done) {
 				cl, err := client.New(cfg, client.Options{})
 				Expect(err).NotTo(HaveOccurred())
@@ -281,6 +330,37 @@ var _ = Describe("Client", func() {
 				close(done)
 			})
 
+			It("should populate the generated name from the server response", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("encoding the deployment as unstructured with a GenerateName")
+				dep.Name = ""
+				dep.GenerateName = "deployment-generated-"
+				u := &unstructured.Unstructured{}
+				scheme.Convert(dep, u, nil)
+				u.SetGroupVersionKind(schema.GroupVersionKind{
+					Group:   "apps",
+					Kind:    "Deployment",
+					Version: "v1",
+				})
+
+				By("creating the object")
+				err = cl.Create(context.TODO(), u)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("writing the generated name and resourceVersion back to the object")
+				Expect(u.GetName()).To(HavePrefix("deployment-generated-"))
+				Expect(u.GetResourceVersion()).NotTo(BeEmpty())
+
+				// let the cleanup find the created object
+				dep.Name = u.GetName()
+				_, err = clientset.AppsV1().Deployments(ns).Get(dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				close(done)
+			})
+
 			It("should create a new non-namespace object ", func(done Done) {
 				cl, err := client.New(cfg, client.Options{})
 				Expect(err).NotTo(HaveOccurred())
@@ -393,6 +473,53 @@ var _ = Describe("Client", func() {
 				close(done)
 			})
 
+			It("should update an existing object from a go struct using protobuf", func(done Done) {
+				cl, err := client.New(cfg, client.Options{UseProtobuf: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("updating the Deployment")
+				dep.Annotations = map[string]string{"foo": "bar"}
+				err = cl.Update(context.TODO(), dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("validating updated Deployment has new annotation")
+				actual, err := clientset.AppsV1().Deployments(ns).Get(dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual.Annotations["foo"]).To(Equal("bar"))
+				Expect(dep).To(Equal(actual))
+
+				close(done)
+			})
+
+			It("should refresh the resourceVersion of the go struct", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(dep)
+				Expect(err).NotTo(HaveOccurred())
+				oldResourceVersion := dep.ResourceVersion
+
+				By("updating the Deployment")
+				dep.Annotations = map[string]string{"foo": "bar"}
+				err = cl.Update(context.TODO(), dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("validating the resourceVersion was written back to the go struct")
+				actual, err := clientset.AppsV1().Deployments(ns).Get(dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(dep.ResourceVersion).NotTo(Equal(oldResourceVersion))
+				Expect(dep.ResourceVersion).To(Equal(actual.ResourceVersion))
+
+				close(done)
+			})
+
 			It("should update an existing object non-namespace object from a go struct", func(done Done) {
 				cl, err := client.New(cfg, client.Options{})
 				Expect(err).NotTo(HaveOccurred())
@@ -539,6 +666,104 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Patch", func() {
+		Context("with structured objects", func() {
+			It("should patch an existing object from a go struct", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("patching the Deployment")
+				patch := client.ConstantPatch(types.MergePatchType, []byte(`{"metadata":{"annotations":{"foo":"bar"}}}`))
+				err = cl.Patch(context.TODO(), dep, patch)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("validating the passed in object was updated from the server response")
+				Expect(dep.Annotations["foo"]).To(Equal("bar"))
+
+				By("validating patched Deployment has new annotation")
+				actual, err := clientset.AppsV1().Deployments(ns).Get(dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual).NotTo(BeNil())
+				Expect(actual.Annotations["foo"]).To(Equal("bar"))
+
+				close(done)
+			})
+
+			It("should patch an existing non-namespace object from a go struct", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				node, err := clientset.CoreV1().Nodes().Create(node)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("patching the object")
+				patch := client.ConstantPatch(types.StrategicMergePatchType, []byte(`{"metadata":{"annotations":{"foo":"bar"}}}`))
+				err = cl.Patch(context.TODO(), node, patch)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("validate patched Node had new annotation")
+				actual, err := clientset.CoreV1().Nodes().Get(node.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual).NotTo(BeNil())
+				Expect(actual.Annotations["foo"]).To(Equal("bar"))
+
+				close(done)
+			})
+
+			It("should fail if the object does not exists", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("patching non-existent object")
+				patch := client.ConstantPatch(types.MergePatchType, []byte(`{"metadata":{"annotations":{"foo":"bar"}}}`))
+				err = cl.Patch(context.TODO(), dep, patch)
+				Expect(err).To(HaveOccurred())
+
+				close(done)
+			})
+		})
+
+		Context("with unstructured objects", func() {
+			It("should patch an existing object", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("patching the Deployment")
+				u := &unstructured.Unstructured{}
+				scheme.Convert(dep, u, nil)
+				u.SetGroupVersionKind(schema.GroupVersionKind{
+					Group:   "apps",
+					Kind:    "Deployment",
+					Version: "v1",
+				})
+				patch := client.ConstantPatch(types.MergePatchType, []byte(`{"metadata":{"annotations":{"foo":"bar"}}}`))
+				err = cl.Patch(context.TODO(), u, patch)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(u.GetAnnotations()).To(HaveKeyWithValue("foo", "bar"))
+
+				By("validating patched Deployment has new annotation")
+				actual, err := clientset.AppsV1().Deployments(ns).Get(dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual).NotTo(BeNil())
+				Expect(actual.Annotations["foo"]).To(Equal("bar"))
+
+				close(done)
+			})
+		})
+	})
+
 	Describe("StatusClient", func() {
 		Context("with structured objects", func() {
 			It("should update status of an existing object", func(done Done) {
@@ -941,6 +1166,27 @@ var _ = Describe("Client", func() {
 				close(done)
 			})
 
+			It("should fetch an existing object for a go struct using protobuf", func(done Done) {
+				By("first creating the Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				cl, err := client.New(cfg, client.Options{UseProtobuf: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("fetching the created Deployment")
+				var actual appsv1.Deployment
+				key := client.ObjectKey{Namespace: ns, Name: dep.Name}
+				err = cl.Get(context.TODO(), key, &actual)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("validating the fetched deployment equals the created one")
+				Expect(dep).To(Equal(&actual))
+
+				close(done)
+			})
+
 			It("should fetch an existing non-namespace object for a go struct", func(done Done) {
 				By("first creating the object")
 				node, err := clientset.CoreV1().Nodes().Create(node)
@@ -1110,6 +1356,31 @@ var _ = Describe("Client", func() {
 				close(done)
 			}, serverSideTimeoutSeconds)
 
+			It("should fetch collection of objects using protobuf", func(done Done) {
+				By("creating an initial object")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(dep)
+				Expect(err).NotTo(HaveOccurred())
+
+				cl, err := client.New(cfg, client.Options{UseProtobuf: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("listing all objects of that type in the cluster")
+				deps := &appsv1.DeploymentList{}
+				Expect(cl.List(context.Background(), nil, deps)).NotTo(HaveOccurred())
+
+				var found *appsv1.Deployment
+				for i := range deps.Items {
+					if deps.Items[i].Name == dep.Name && deps.Items[i].Namespace == dep.Namespace {
+						found = &deps.Items[i]
+						break
+					}
+				}
+				Expect(found).NotTo(BeNil())
+				Expect(found.Spec).To(Equal(dep.Spec))
+
+				close(done)
+			}, serverSideTimeoutSeconds)
+
 			It("should fetch unstructured collection of objects", func(done Done) {
 				By("create an initial object")
 				_, err := clientset.AppsV1().Deployments(ns).Create(dep)
@@ -1846,6 +2117,147 @@ var _ = Describe("Client", func() {
 	})
 })
 
+var _ = Describe("Protobuf", func() {
+	var server *httptest.Server
+	var mu sync.Mutex
+	var accept string
+	var mapper *meta.DefaultRESTMapper
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		accept = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			accept = r.Header.Get("Accept")
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+		}))
+
+		scheme = runtime.NewScheme()
+		kscheme.AddToScheme(scheme)
+		scheme.AddKnownTypeWithName(testCRDGroupVersion.WithKind("TestCRD"), &testCRD{})
+
+		mapper = meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion, testCRDGroupVersion})
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+		mapper.Add(testCRDGroupVersion.WithKind("TestCRD"), meta.RESTScopeNamespace)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	lastAccept := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return accept
+	}
+
+	It("should request protobuf for built-in types when UseProtobuf is set", func() {
+		cl, err := client.New(&rest.Config{Host: server.URL}, client.Options{
+			Scheme: scheme, Mapper: mapper, UseProtobuf: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		cl.Get(context.TODO(), client.ObjectKey{Namespace: "ns", Name: "name"}, &corev1.Pod{})
+		Expect(lastAccept()).To(HavePrefix("application/vnd.kubernetes.protobuf"))
+	})
+
+	It("should send and receive protobuf bodies for built-in types when UseProtobuf is set", func() {
+		codecs := serializer.NewCodecFactory(scheme)
+		info, ok := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), "application/vnd.kubernetes.protobuf")
+		Expect(ok).To(BeTrue())
+		encoder := codecs.EncoderForVersion(info.Serializer, corev1.SchemeGroupVersion)
+
+		var contentTypes []string
+		pbServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			var resp runtime.Object
+			switch {
+			case r.Method == http.MethodPost || r.Method == http.MethodPut:
+				mu.Lock()
+				contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+				mu.Unlock()
+				body, err := ioutil.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				pod := &corev1.Pod{}
+				_, _, err = info.Serializer.Decode(body, nil, pod)
+				Expect(err).NotTo(HaveOccurred())
+				pod.ResourceVersion = r.Method
+				resp = pod
+			case r.URL.Path == "/api/v1/namespaces/ns/pods":
+				resp = &corev1.PodList{Items: []corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "ns"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Namespace: "ns"}},
+				}}
+			default:
+				resp = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "ns"},
+					Spec:       corev1.PodSpec{NodeName: "node"},
+				}
+			}
+			w.Header().Set("Content-Type", "application/vnd.kubernetes.protobuf")
+			Expect(encoder.Encode(resp, w)).To(Succeed())
+		}))
+		defer pbServer.Close()
+
+		cl, err := client.New(&rest.Config{Host: pbServer.URL}, client.Options{
+			Scheme: scheme, Mapper: mapper, UseProtobuf: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("creating and updating a Pod")
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "ns"}}
+		Expect(cl.Create(context.TODO(), pod)).To(Succeed())
+		Expect(pod.ResourceVersion).To(Equal(http.MethodPost))
+		Expect(cl.Update(context.TODO(), pod)).To(Succeed())
+		Expect(pod.ResourceVersion).To(Equal(http.MethodPut))
+		mu.Lock()
+		Expect(contentTypes).To(ConsistOf("application/vnd.kubernetes.protobuf", "application/vnd.kubernetes.protobuf"))
+		mu.Unlock()
+
+		By("getting and listing Pods")
+		actual := &corev1.Pod{}
+		Expect(cl.Get(context.TODO(), client.ObjectKey{Namespace: "ns", Name: "name"}, actual)).To(Succeed())
+		Expect(actual.Spec.NodeName).To(Equal("node"))
+		list := &corev1.PodList{}
+		Expect(cl.List(context.TODO(), client.InNamespace("ns"), list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(2))
+		Expect(list.Items[1].Name).To(Equal("pod-2"))
+	})
+
+	It("should request JSON for types which do not support protobuf", func() {
+		cl, err := client.New(&rest.Config{Host: server.URL}, client.Options{
+			Scheme: scheme, Mapper: mapper, UseProtobuf: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		cl.Get(context.TODO(), client.ObjectKey{Namespace: "ns", Name: "name"}, &testCRD{})
+		Expect(lastAccept()).NotTo(BeEmpty())
+		Expect(lastAccept()).NotTo(ContainSubstring("protobuf"))
+	})
+
+	It("should request JSON for built-in types by default", func() {
+		cl, err := client.New(&rest.Config{Host: server.URL}, client.Options{
+			Scheme: scheme, Mapper: mapper})
+		Expect(err).NotTo(HaveOccurred())
+
+		cl.Get(context.TODO(), client.ObjectKey{Namespace: "ns", Name: "name"}, &corev1.Pod{})
+		Expect(lastAccept()).NotTo(BeEmpty())
+		Expect(lastAccept()).NotTo(ContainSubstring("protobuf"))
+	})
+})
+
+var testCRDGroupVersion = schema.GroupVersion{Group: "example.com", Version: "v1"}
+
+// testCRD is a type that, like the types of most CRDs, does not support protobuf.
+type testCRD struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+func (t *testCRD) DeepCopyObject() runtime.Object {
+	return &testCRD{TypeMeta: t.TypeMeta, ObjectMeta: *t.ObjectMeta.DeepCopy()}
+}
+
 var _ = Describe("DelegatingReader", func() {
 	Describe("Get", func() {
 		It("should call cache reader when structured object", func() {
@@ -1889,6 +2301,26 @@ var _ = Describe("DelegatingReader", func() {
 			Expect(0).To(Equal(clientReader.Called))
 
 		})
+		It("should call client reader when structured object and UncachedList is set", func() {
+			cachedReader := &fakeReader{}
+			clientReader := &fakeReader{}
+			dReader := client.DelegatingReader{
+				CacheReader:  cachedReader,
+				ClientReader: clientReader,
+				UncachedList: true,
+			}
+			var actual appsv1.DeploymentList
+			dReader.List(context.Background(), nil, &actual)
+			Expect(0).To(Equal(cachedReader.Called))
+			Expect(1).To(Equal(clientReader.Called))
+
+			By("still calling the cache reader for Get")
+			var obj appsv1.Deployment
+			key := client.ObjectKey{Namespace: "ns", Name: "name"}
+			dReader.Get(context.TODO(), key, &obj)
+			Expect(1).To(Equal(cachedReader.Called))
+			Expect(1).To(Equal(clientReader.Called))
+		})
 		It("should call client reader when structured object", func() {
 			cachedReader := &fakeReader{}
 			clientReader := &fakeReader{}
@@ -31,6 +31,9 @@ import (
 var (
 	kubeconfig, masterURL string
 	log                   = logf.KBLog.WithName("client").WithName("config")
+
+	// inClusterConfig loads the in-cluster config, and is replaced in tests
+	inClusterConfig = rest.InClusterConfig
 )
 
 func init() {
@@ -56,7 +59,24 @@ func init() {
 // * In-cluster config if running in cluster
 //
 // * $HOME/.kube/config if exists
+//
+// If --master is set, it overrides the API server address of whichever config is used.
 func GetConfig() (*rest.Config, error) {
+	c, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	// clientcmd only uses --master as a default for the kubeconfig server, and
+	// the in-cluster config ignores it, so apply the override here.
+	if len(masterURL) > 0 {
+		c.Host = masterURL
+	}
+	return c, nil
+}
+
+// loadConfig loads the rest.Config from the first location in the precedence
+// documented on GetConfig.
+func loadConfig() (*rest.Config, error) {
 	// If a flag is specified with the config location, use that
 	if len(kubeconfig) > 0 {
 		return clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
@@ -66,13 +86,13 @@ func GetConfig() (*rest.Config, error) {
 		return clientcmd.BuildConfigFromFlags(masterURL, os.Getenv("KUBECONFIG"))
 	}
 	// If no explicit location, try the in-cluster config
-	if c, err := rest.InClusterConfig(); err == nil {
+	if c, err := inClusterConfig(); err == nil {
 		return c, nil
 	}
 	// If no in-cluster config, try the default location in the user's home directory
 	if usr, err := user.Current(); err == nil {
 		if c, err := clientcmd.BuildConfigFromFlags(
-			"", filepath.Join(usr.HomeDir, ".kube", "config")); err == nil {
+			masterURL, filepath.Join(usr.HomeDir, ".kube", "config")); err == nil {
 			return c, nil
 		}
 	}
@@ -89,6 +109,7 @@ func GetConfigOrDie() *rest.Config {
 	config, err := GetConfig()
 	if err != nil {
 		log.Error(err, "unable to get kubeconfig")
+		os.Exit(1)
 	}
 	return config
 }
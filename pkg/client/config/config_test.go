@@ -0,0 +1,126 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+users:
+- name: test
+  user:
+    token: test-token
+current-context: test
+`
+
+var _ = Describe("Config", func() {
+	var dir string
+	var origKubeconfig, origMasterURL, origEnv string
+	origInClusterConfig := inClusterConfig
+
+	writeKubeconfig := func(name, server string) string {
+		path := filepath.Join(dir, name)
+		err := ioutil.WriteFile(path, []byte(fmt.Sprintf(kubeconfigTemplate, server)), 0600)
+		Expect(err).NotTo(HaveOccurred())
+		return path
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "config-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		origKubeconfig, origMasterURL = kubeconfig, masterURL
+		origEnv = os.Getenv("KUBECONFIG")
+		kubeconfig, masterURL = "", ""
+		Expect(os.Unsetenv("KUBECONFIG")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		kubeconfig, masterURL = origKubeconfig, origMasterURL
+		inClusterConfig = origInClusterConfig
+		Expect(os.Setenv("KUBECONFIG", origEnv)).To(Succeed())
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("GetConfig", func() {
+		It("should use the kubeconfig from the KUBECONFIG environment variable", func() {
+			Expect(os.Setenv("KUBECONFIG", writeKubeconfig("env", "https://from-env:6443"))).To(Succeed())
+
+			cfg, err := GetConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("https://from-env:6443"))
+			Expect(cfg.BearerToken).To(Equal("test-token"))
+		})
+
+		It("should prefer the --kubeconfig flag over the KUBECONFIG environment variable", func() {
+			Expect(os.Setenv("KUBECONFIG", writeKubeconfig("env", "https://from-env:6443"))).To(Succeed())
+			kubeconfig = writeKubeconfig("flag", "https://from-flag:6443")
+
+			cfg, err := GetConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("https://from-flag:6443"))
+		})
+
+		It("should override the server of the --kubeconfig flag with the --master flag", func() {
+			kubeconfig = writeKubeconfig("flag", "https://from-flag:6443")
+			masterURL = "https://from-master:6443"
+
+			cfg, err := GetConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("https://from-master:6443"))
+			Expect(cfg.BearerToken).To(Equal("test-token"))
+		})
+
+		It("should override the server of the in-cluster config with the --master flag", func() {
+			inClusterConfig = func() (*rest.Config, error) {
+				return &rest.Config{Host: "https://in-cluster:443", BearerToken: "in-cluster-token"}, nil
+			}
+			masterURL = "https://from-master:6443"
+
+			cfg, err := GetConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("https://from-master:6443"))
+			Expect(cfg.BearerToken).To(Equal("in-cluster-token"))
+		})
+
+		It("should fail if the --kubeconfig flag points at a missing file", func() {
+			kubeconfig = filepath.Join(dir, "missing")
+
+			_, err := GetConfig()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
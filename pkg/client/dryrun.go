@@ -0,0 +1,266 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// NewDryRunClient returns a Client that reads through c but only logs the
+// writes made through it, without sending them to the API server.  Updates
+// are logged with a merge patch from the current state of the object when it
+// can be read.  The values of Secrets are never logged.
+//
+// Since nothing is written, objects passed to Create, Update and Patch are not
+// updated with content from the Server.
+func NewDryRunClient(c Client, log logr.Logger) Client {
+	return &dryRunClient{Client: c, log: log.WithValues("dryRun", true)}
+}
+
+// dryRunClient is a Client that logs and skips writes.
+type dryRunClient struct {
+	Client
+	log logr.Logger
+}
+
+var _ Client = &dryRunClient{}
+
+// Create implements client.Client
+func (c *dryRunClient) Create(ctx context.Context, obj runtime.Object) error {
+	c.logWrite("create", obj, "object", redactedJSON(obj))
+	return nil
+}
+
+// Update implements client.Client
+func (c *dryRunClient) Update(ctx context.Context, obj runtime.Object) error {
+	c.logWrite("update", obj, "diff", c.diff(ctx, obj))
+	return nil
+}
+
+// Delete implements client.Client
+func (c *dryRunClient) Delete(ctx context.Context, obj runtime.Object, opts ...DeleteOptionFunc) error {
+	c.logWrite("delete", obj)
+	return nil
+}
+
+// Patch implements client.Client
+func (c *dryRunClient) Patch(ctx context.Context, obj runtime.Object, patch Patch) error {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	if isSecret(obj) {
+		data = redactSecretPatch(patch.Type(), data)
+	}
+	c.logWrite("patch", obj, "patchType", patch.Type(), "patch", string(data))
+	return nil
+}
+
+// Status implements client.StatusClient
+func (c *dryRunClient) Status() StatusWriter {
+	return &dryRunStatusWriter{client: c}
+}
+
+// logWrite logs a skipped write of obj.
+func (c *dryRunClient) logWrite(verb string, obj runtime.Object, keysAndValues ...interface{}) {
+	keysAndValues = append([]interface{}{"verb", verb, "type", reflect.TypeOf(obj).String()}, keysAndValues...)
+	if key, err := ObjectKeyFromObject(obj); err == nil {
+		keysAndValues = append(keysAndValues, "namespace", key.Namespace, "name", key.Name)
+	}
+	c.log.Info("skipping write", keysAndValues...)
+}
+
+// diff returns a merge patch from the current state of obj in the cluster to
+// obj, or the whole of obj if the current state cannot be read.  Secret values
+// are redacted in either case.
+func (c *dryRunClient) diff(ctx context.Context, obj runtime.Object) string {
+	key, err := ObjectKeyFromObject(obj)
+	if err != nil {
+		return redactedJSON(obj)
+	}
+	current := newEmptyObject(obj)
+	if err := c.Get(ctx, key, current); err != nil {
+		return redactedJSON(obj)
+	}
+	originalMap, err := toJSONMap(current)
+	if err != nil {
+		return redactedJSON(obj)
+	}
+	modifiedMap, err := toJSONMap(obj)
+	if err != nil {
+		return ""
+	}
+	if isSecret(obj) {
+		// Mark changed values in the modified object, so that the patch
+		// still shows which keys changed without showing their values
+		for _, field := range secretDataFields {
+			redactValues(modifiedMap, field, nestedMap(originalMap, field), redactedChanged)
+			redactValues(originalMap, field, nil, redacted)
+		}
+	}
+	original, err := json.Marshal(originalMap)
+	if err != nil {
+		return redactedJSON(obj)
+	}
+	modified, err := json.Marshal(modifiedMap)
+	if err != nil {
+		return ""
+	}
+	patch, err := jsonpatch.CreateMergePatch(original, modified)
+	if err != nil {
+		return string(modified)
+	}
+	return string(patch)
+}
+
+// dryRunStatusWriter logs and skips status updates.
+type dryRunStatusWriter struct {
+	client *dryRunClient
+}
+
+// Update implements client.StatusWriter
+func (sw *dryRunStatusWriter) Update(ctx context.Context, obj runtime.Object) error {
+	sw.client.logWrite("update", obj, "subresource", "status", "diff", sw.client.diff(ctx, obj))
+	return nil
+}
+
+// newEmptyObject returns a new, empty object of the same type as obj.
+// Unstructured objects keep their GroupVersionKind.
+func newEmptyObject(obj runtime.Object) runtime.Object {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		empty := &unstructured.Unstructured{}
+		empty.SetGroupVersionKind(u.GroupVersionKind())
+		return empty
+	}
+	return reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+}
+
+const (
+	// redacted replaces secret values in logs
+	redacted = "[redacted]"
+
+	// redactedChanged replaces secret values in logs which differ from the
+	// current value
+	redactedChanged = "[redacted, changed]"
+)
+
+// secretDataFields are the fields of a Secret holding secret values.
+var secretDataFields = []string{"data", "stringData"}
+
+// isSecret returns true if obj is a typed or unstructured core Secret.
+func isSecret(obj runtime.Object) bool {
+	if _, ok := obj.(*corev1.Secret); ok {
+		return true
+	}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.GroupVersionKind() == corev1.SchemeGroupVersion.WithKind("Secret")
+	}
+	return false
+}
+
+// toJSONMap returns the JSON representation of obj as a map.
+func toJSONMap(obj runtime.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	err = json.Unmarshal(data, &m)
+	return m, err
+}
+
+// nestedMap returns the map in field of m, or nil if there is none.
+func nestedMap(m map[string]interface{}, field string) map[string]interface{} {
+	nested, _ := m[field].(map[string]interface{})
+	return nested
+}
+
+// redactValues replaces every value of the map in field of m with replacement,
+// except for values equal to those in unchanged, which are replaced with
+// redacted.
+func redactValues(m map[string]interface{}, field string, unchanged map[string]interface{}, replacement string) {
+	values := nestedMap(m, field)
+	for k, v := range values {
+		if old, ok := unchanged[k]; ok && reflect.DeepEqual(old, v) {
+			values[k] = redacted
+		} else {
+			values[k] = replacement
+		}
+	}
+}
+
+// redactedJSON returns obj as JSON with secret values redacted, or an empty
+// string if it cannot be marshalled.
+func redactedJSON(obj runtime.Object) string {
+	m, err := toJSONMap(obj)
+	if err != nil {
+		return ""
+	}
+	if isSecret(obj) {
+		for _, field := range secretDataFields {
+			redactValues(m, field, nil, redacted)
+		}
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// redactSecretPatch returns data, a patch of a Secret, with its values redacted.
+// JSON patches have the value of every operation redacted, and merge patches
+// their secret values.  Patches which cannot be parsed are redacted entirely.
+func redactSecretPatch(patchType types.PatchType, data []byte) []byte {
+	var redactedPatch interface{}
+	switch patchType {
+	case types.JSONPatchType:
+		var ops []map[string]interface{}
+		if err := json.Unmarshal(data, &ops); err != nil {
+			return []byte(redacted)
+		}
+		for _, op := range ops {
+			if _, ok := op["value"]; ok {
+				op["value"] = redacted
+			}
+		}
+		redactedPatch = ops
+	default:
+		m := map[string]interface{}{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return []byte(redacted)
+		}
+		for _, field := range secretDataFields {
+			redactValues(m, field, nil, redacted)
+		}
+		redactedPatch = m
+	}
+	out, err := json.Marshal(redactedPatch)
+	if err != nil {
+		return []byte(redacted)
+	}
+	return out
+}
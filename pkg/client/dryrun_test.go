@@ -0,0 +1,179 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tsungming/controller-runtime/pkg/client"
+	"github.com/tsungming/controller-runtime/pkg/client/fake"
+	logf "github.com/tsungming/controller-runtime/pkg/runtime/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("DryRunClient", func() {
+	var backing client.Client
+	var cl client.Client
+	var out *bytes.Buffer
+	var existing *corev1.ConfigMap
+
+	BeforeEach(func() {
+		existing = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "dry-run"},
+			Data:       map[string]string{"foo": "bar"},
+		}
+		backing = fake.NewFakeClient(existing.DeepCopy())
+		out = &bytes.Buffer{}
+		cl = client.NewDryRunClient(backing, logf.ZapLoggerTo(out, true))
+	})
+
+	getExisting := func() *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{}
+		Expect(backing.Get(context.TODO(), client.ObjectKey{Namespace: "dry-run", Name: "existing"}, cm)).To(Succeed())
+		return cm
+	}
+
+	It("should read through the wrapped client", func() {
+		cm := &corev1.ConfigMap{}
+		Expect(cl.Get(context.TODO(), client.ObjectKey{Namespace: "dry-run", Name: "existing"}, cm)).To(Succeed())
+		Expect(cm.Data).To(Equal(existing.Data))
+	})
+
+	It("should log but not perform creates", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "dry-run"}}
+		Expect(cl.Create(context.TODO(), cm)).To(Succeed())
+
+		err := backing.Get(context.TODO(), client.ObjectKey{Namespace: "dry-run", Name: "new"}, &corev1.ConfigMap{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(out.String()).To(ContainSubstring("skipping write"))
+		Expect(out.String()).To(ContainSubstring(`"verb": "create"`))
+		Expect(out.String()).To(ContainSubstring(`"name": "new"`))
+	})
+
+	It("should log the diff of updates without performing them", func() {
+		cm := getExisting()
+		cm.Data["foo"] = "baz"
+		Expect(cl.Update(context.TODO(), cm)).To(Succeed())
+
+		Expect(getExisting().Data).To(Equal(existing.Data))
+		Expect(out.String()).To(ContainSubstring(`"verb": "update"`))
+		Expect(out.String()).To(ContainSubstring(`{\"data\":{\"foo\":\"baz\"}}`))
+	})
+
+	It("should log but not perform status updates", func() {
+		cm := getExisting()
+		cm.Data["foo"] = "baz"
+		Expect(cl.Status().Update(context.TODO(), cm)).To(Succeed())
+
+		Expect(getExisting().Data).To(Equal(existing.Data))
+		Expect(out.String()).To(ContainSubstring(`"subresource": "status"`))
+	})
+
+	It("should log but not perform patches", func() {
+		patch := client.ConstantPatch(types.MergePatchType, []byte(`{"data":{"foo":"baz"}}`))
+		Expect(cl.Patch(context.TODO(), getExisting(), patch)).To(Succeed())
+
+		Expect(getExisting().Data).To(Equal(existing.Data))
+		Expect(out.String()).To(ContainSubstring(`"verb": "patch"`))
+		Expect(out.String()).To(ContainSubstring(`"patchType": "application/merge-patch+json"`))
+	})
+
+	It("should log but not perform deletes", func() {
+		Expect(cl.Delete(context.TODO(), getExisting())).To(Succeed())
+
+		getExisting()
+		Expect(out.String()).To(ContainSubstring(`"verb": "delete"`))
+	})
+
+	Describe("with Secrets", func() {
+		const secretValue = "s3cr3t-value"
+		var secret *corev1.Secret
+
+		BeforeEach(func() {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "dry-run"},
+				Data:       map[string][]byte{"password": []byte(secretValue), "user": []byte(secretValue + "-user")},
+			}
+			backing = fake.NewFakeClient(secret.DeepCopy())
+			cl = client.NewDryRunClient(backing, logf.ZapLoggerTo(out, true))
+		})
+
+		expectNoSecretValues := func() {
+			Expect(out.String()).To(ContainSubstring("skipping write"))
+			Expect(out.String()).NotTo(ContainSubstring(secretValue))
+			Expect(out.String()).NotTo(ContainSubstring(base64.StdEncoding.EncodeToString([]byte(secretValue))))
+			Expect(out.String()).NotTo(ContainSubstring("new-value"))
+			Expect(out.String()).NotTo(ContainSubstring(base64.StdEncoding.EncodeToString([]byte("new-value"))))
+		}
+
+		It("should not log the values of created Secrets", func() {
+			secret.Name = "new"
+			secret.StringData = map[string]string{"token": "new-value"}
+			Expect(cl.Create(context.TODO(), secret)).To(Succeed())
+
+			expectNoSecretValues()
+			Expect(out.String()).To(ContainSubstring("password"))
+			Expect(out.String()).To(ContainSubstring("token"))
+		})
+
+		It("should not log the values of created unstructured Secrets", func() {
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion("v1")
+			u.SetKind("Secret")
+			u.SetName("new")
+			u.SetNamespace("dry-run")
+			Expect(unstructured.SetNestedStringMap(u.Object, map[string]string{"token": "new-value"}, "stringData")).To(Succeed())
+			Expect(cl.Create(context.TODO(), u)).To(Succeed())
+
+			expectNoSecretValues()
+			Expect(out.String()).To(ContainSubstring("token"))
+		})
+
+		It("should only log which values of updated Secrets changed", func() {
+			secret.Data["password"] = []byte("new-value")
+			Expect(cl.Update(context.TODO(), secret)).To(Succeed())
+
+			expectNoSecretValues()
+			Expect(out.String()).To(ContainSubstring(`{\"data\":{\"password\":\"[redacted, changed]\"}}`))
+		})
+
+		It("should not log the values of Secrets whose current state cannot be read", func() {
+			secret.Name = "missing"
+			Expect(cl.Status().Update(context.TODO(), secret)).To(Succeed())
+
+			expectNoSecretValues()
+		})
+
+		It("should not log the values in patches of Secrets", func() {
+			mergePatch := client.ConstantPatch(types.MergePatchType, []byte(`{"stringData":{"password":"new-value"}}`))
+			Expect(cl.Patch(context.TODO(), secret, mergePatch)).To(Succeed())
+			jsonPatch := client.ConstantPatch(types.JSONPatchType, []byte(`[{"op":"add","path":"/stringData","value":{"password":"new-value"}}]`))
+			Expect(cl.Patch(context.TODO(), secret, jsonPatch)).To(Succeed())
+
+			expectNoSecretValues()
+			Expect(out.String()).To(ContainSubstring("/stringData"))
+		})
+	})
+})
@@ -19,14 +19,21 @@ package fake
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
+	"reflect"
+	"strings"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/tsungming/controller-runtime/pkg/client"
 	"github.com/tsungming/controller-runtime/pkg/client/apiutil"
 	logf "github.com/tsungming/controller-runtime/pkg/runtime/log"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/testing"
 )
@@ -77,9 +84,16 @@ func (c *fakeClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.
 }
 
 func (c *fakeClient) List(ctx context.Context, opts *client.ListOptions, list runtime.Object) error {
-	gvk := opts.Raw.TypeMeta.GroupVersionKind()
+	gvk, err := getGVKFromList(opts, list)
+	if err != nil {
+		return err
+	}
 	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
-	o, err := c.tracker.List(gvr, gvk, opts.Namespace)
+	namespace := ""
+	if opts != nil {
+		namespace = opts.Namespace
+	}
+	o, err := c.tracker.List(gvr, gvk, namespace)
 	if err != nil {
 		return err
 	}
@@ -101,6 +115,9 @@ func (c *fakeClient) Create(ctx context.Context, obj runtime.Object) error {
 	if err != nil {
 		return err
 	}
+	if accessor.GetName() == "" && accessor.GetGenerateName() != "" {
+		accessor.SetName(accessor.GetGenerateName() + utilrand.String(5))
+	}
 	return c.tracker.Create(gvr, obj, accessor.GetNamespace())
 }
 
@@ -129,6 +146,45 @@ func (c *fakeClient) Update(ctx context.Context, obj runtime.Object) error {
 	return c.tracker.Update(gvr, obj, accessor.GetNamespace())
 }
 
+func (c *fakeClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch) error {
+	gvr, err := getGVRFromObject(obj)
+	if err != nil {
+		return err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	o, err := c.tracker.Get(gvr, accessor.GetNamespace(), accessor.GetName())
+	if err != nil {
+		return err
+	}
+	original, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	patched, err := applyPatch(patch.Type(), original, data, obj)
+	if err != nil {
+		return err
+	}
+	// Decode into a new object rather than obj, so that changes made to obj
+	// locally which are not part of the patch are not stored.
+	patchedObj := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	decoder := scheme.Codecs.UniversalDecoder()
+	if _, _, err = decoder.Decode(patched, nil, patchedObj); err != nil {
+		return err
+	}
+	if err := c.tracker.Update(gvr, patchedObj, accessor.GetNamespace()); err != nil {
+		return err
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(patchedObj).Elem())
+	return nil
+}
+
 func (c *fakeClient) Status() client.StatusWriter {
 	return &fakeStatusWriter{client: c}
 }
@@ -142,6 +198,43 @@ func getGVRFromObject(obj runtime.Object) (schema.GroupVersionResource, error) {
 	return gvr, nil
 }
 
+// getGVKFromList returns the GroupVersionKind of the items to list.  The kind
+// given in opts.Raw takes precedence, since generic lists such as metav1.List
+// do not identify their item type.  Otherwise it is derived from the list type.
+func getGVKFromList(opts *client.ListOptions, list runtime.Object) (schema.GroupVersionKind, error) {
+	if opts != nil && opts.Raw != nil && opts.Raw.Kind != "" {
+		return opts.Raw.TypeMeta.GroupVersionKind(), nil
+	}
+	gvk, err := apiutil.GVKForObject(list, scheme.Scheme)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	if !strings.HasSuffix(gvk.Kind, "List") {
+		return schema.GroupVersionKind{}, fmt.Errorf("non-list type %T (kind %q) passed as output", list, gvk)
+	}
+	gvk.Kind = gvk.Kind[:len(gvk.Kind)-4]
+	return gvk, nil
+}
+
+// applyPatch applies data of the given patch type to the JSON document original.
+// dataStruct is used to look up the merge strategy for strategic merge patches.
+func applyPatch(patchType types.PatchType, original, data []byte, dataStruct interface{}) ([]byte, error) {
+	switch patchType {
+	case types.JSONPatchType:
+		p, err := jsonpatch.DecodePatch(data)
+		if err != nil {
+			return nil, err
+		}
+		return p.Apply(original)
+	case types.MergePatchType:
+		return jsonpatch.MergePatch(original, data)
+	case types.StrategicMergePatchType:
+		return strategicpatch.StrategicMergePatch(original, data, dataStruct)
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", patchType)
+	}
+}
+
 type fakeStatusWriter struct {
 	client *fakeClient
 }
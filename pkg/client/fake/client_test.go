@@ -86,6 +86,44 @@ var _ = Describe("Fake client", func() {
 		Expect(list.Items).To(ConsistOf(expectedDep))
 	})
 
+	It("should be able to List using a typed list", func() {
+		By("Listing all configmaps in a namespace")
+		list := &corev1.ConfigMapList{}
+		err := cl.List(nil, client.InNamespace("ns2"), list)
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Name).To(Equal("test-cm"))
+	})
+
+	It("should prefer the kind in the raw list options over the list type", func() {
+		By("Listing deployments into a list type for configmaps")
+		list := &corev1.ConfigMapList{}
+		err := cl.List(nil, &client.ListOptions{
+			Namespace: "ns1",
+			Raw: &metav1.ListOptions{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+			},
+		}, list)
+		Expect(err).To(BeNil())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Name).To(Equal("test-deployment"))
+	})
+
+	It("should fail to List into a generic list without a kind", func() {
+		By("Listing into a metav1.List without setting the kind in the raw list options")
+		err := cl.List(nil, client.InNamespace("ns1"), &metav1.List{})
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should fail to List into a type which is not a list", func() {
+		By("Listing into a configmap")
+		err := cl.List(nil, client.InNamespace("ns2"), &corev1.ConfigMap{})
+		Expect(err).To(MatchError(ContainSubstring("non-list type")))
+	})
+
 	It("should be able to Create", func() {
 		By("Creating a new configmap")
 		newcm := &corev1.ConfigMap{
@@ -108,6 +146,25 @@ var _ = Describe("Fake client", func() {
 		Expect(obj).To(Equal(newcm))
 	})
 
+	It("should be able to Create with a generated name", func() {
+		By("Creating a new configmap with GenerateName")
+		newcm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "new-test-cm-",
+				Namespace:    "ns2",
+			},
+		}
+		err := cl.Create(nil, newcm)
+		Expect(err).To(BeNil())
+		Expect(newcm.Name).To(HavePrefix("new-test-cm-"))
+
+		By("Getting the new configmap by its generated name")
+		obj := &corev1.ConfigMap{}
+		err = cl.Get(nil, types.NamespacedName{Name: newcm.Name, Namespace: "ns2"}, obj)
+		Expect(err).To(BeNil())
+		Expect(obj).To(Equal(newcm))
+	})
+
 	It("should be able to Update", func() {
 		By("Updating a new configmap")
 		newcm := &corev1.ConfigMap{
@@ -152,4 +209,57 @@ var _ = Describe("Fake client", func() {
 		Expect(err).To(BeNil())
 		Expect(list.Items).To(HaveLen(0))
 	})
+	It("should be able to Patch", func() {
+		By("Patching a configmap")
+		patch := client.ConstantPatch(types.MergePatchType, []byte(`{"data":{"test-key":"new-value"}}`))
+		err := cl.Patch(nil, cm, patch)
+		Expect(err).To(BeNil())
+		Expect(cm.Data).To(HaveKeyWithValue("test-key", "new-value"))
+
+		By("Getting the patched configmap")
+		namespacedName := types.NamespacedName{
+			Name:      "test-cm",
+			Namespace: "ns2",
+		}
+		obj := &corev1.ConfigMap{}
+		err = cl.Get(nil, namespacedName, obj)
+		Expect(err).To(BeNil())
+		Expect(obj.Data).To(HaveKeyWithValue("test-key", "new-value"))
+	})
+
+	It("should not store local changes that are not part of the Patch", func() {
+		By("Changing the configmap locally without sending the change")
+		cm.Data["local-unsent"] = "local-value"
+		cm.Labels = map[string]string{"local": "label"}
+
+		By("Patching a different key of the configmap")
+		patch := client.ConstantPatch(types.MergePatchType, []byte(`{"data":{"test-key":"new-value"}}`))
+		err := cl.Patch(nil, cm, patch)
+		Expect(err).To(BeNil())
+		Expect(cm.Data).To(Equal(map[string]string{"test-key": "new-value"}))
+		Expect(cm.Labels).To(BeEmpty())
+
+		By("Getting the patched configmap")
+		obj := &corev1.ConfigMap{}
+		err = cl.Get(nil, types.NamespacedName{Name: "test-cm", Namespace: "ns2"}, obj)
+		Expect(err).To(BeNil())
+		Expect(obj.Data).To(Equal(map[string]string{"test-key": "new-value"}))
+		Expect(obj.Labels).To(BeEmpty())
+	})
+
+	It("should be usable where only a Reader is needed", func() {
+		By("Passing the client to a function that accepts a client.Reader")
+		data, err := readConfigMapData(cl, types.NamespacedName{Name: "test-cm", Namespace: "ns2"})
+		Expect(err).To(BeNil())
+		Expect(data).To(HaveKeyWithValue("test-key", "test-value"))
+	})
 })
+
+// readConfigMapData only needs read access, so it accepts a client.Reader.
+func readConfigMapData(r client.Reader, key types.NamespacedName) (map[string]string, error) {
+	obj := &corev1.ConfigMap{}
+	if err := r.Get(nil, key, obj); err != nil {
+		return nil, err
+	}
+	return obj.Data, nil
+}
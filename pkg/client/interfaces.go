@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,15 +31,26 @@ import (
 // ObjectKey identifies a Kubernetes Object.
 type ObjectKey = types.NamespacedName
 
-// ObjectKeyFromObject returns the ObjectKey given a runtime.Object
+// ObjectKeyFromObject returns the ObjectKey given a runtime.Object.  It returns an
+// error if obj has no object metadata, e.g. because it is a list.
 func ObjectKeyFromObject(obj runtime.Object) (ObjectKey, error) {
 	accessor, err := meta.Accessor(obj)
 	if err != nil {
-		return ObjectKey{}, err
+		return ObjectKey{}, fmt.Errorf("cannot get object key from %T without object metadata: %v", obj, err)
 	}
 	return ObjectKey{Namespace: accessor.GetNamespace(), Name: accessor.GetName()}, nil
 }
 
+// MustObjectKeyFromObject is like ObjectKeyFromObject, but panics if obj has
+// no object metadata.
+func MustObjectKeyFromObject(obj runtime.Object) ObjectKey {
+	key, err := ObjectKeyFromObject(obj)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
 // TODO(directxman12): is there a sane way to deal with get/delete options?
 
 // Reader knows how to read and list Kubernetes objects.
@@ -56,15 +68,22 @@ type Reader interface {
 
 // Writer knows how to create, delete, and update Kubernetes objects.
 type Writer interface {
-	// Create saves the object obj in the Kubernetes cluster.
+	// Create saves the object obj in the Kubernetes cluster. obj must be a
+	// struct pointer so that obj can be updated with the content returned by the Server,
+	// including the name generated from obj's GenerateName and the resourceVersion.
 	Create(ctx context.Context, obj runtime.Object) error
 
 	// Delete deletes the given obj from Kubernetes cluster.
 	Delete(ctx context.Context, obj runtime.Object, opts ...DeleteOptionFunc) error
 
 	// Update updates the given obj in the Kubernetes cluster. obj must be a
-	// struct pointer so that obj can be updated with the content returned by the Server.
+	// struct pointer so that obj can be updated with the content returned by the Server,
+	// including the new resourceVersion.
 	Update(ctx context.Context, obj runtime.Object) error
+
+	// Patch patches the given obj in the Kubernetes cluster. obj must be a
+	// struct pointer so that obj can be updated with the content returned by the Server.
+	Patch(ctx context.Context, obj runtime.Object, patch Patch) error
 }
 
 // StatusClient knows how to create a client which can update status subresource
@@ -82,6 +101,8 @@ type StatusWriter interface {
 }
 
 // Client knows how to perform CRUD operations on Kubernetes objects.
+// Code that only needs to read objects should accept a Reader instead,
+// and code that only needs to write objects should accept a Writer.
 type Client interface {
 	Reader
 	Writer
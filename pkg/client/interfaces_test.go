@@ -0,0 +1,45 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tsungming/controller-runtime/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ObjectKeyFromObject", func() {
+	It("should return the namespace and name of the object", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}}
+		key, err := client.ObjectKeyFromObject(cm)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal(client.ObjectKey{Namespace: "bar", Name: "foo"}))
+		Expect(client.MustObjectKeyFromObject(cm)).To(Equal(key))
+	})
+
+	It("should return an error naming the type of an object without metadata", func() {
+		_, err := client.ObjectKeyFromObject(&corev1.ConfigMapList{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("*v1.ConfigMapList"))
+	})
+
+	It("should panic in the must variant for an object without metadata", func() {
+		Expect(func() { client.MustObjectKeyFromObject(&corev1.ConfigMapList{}) }).To(Panic())
+	})
+})
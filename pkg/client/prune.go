@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tsungming/controller-runtime/pkg/client/apiutil"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Prune deletes the objects of the given kinds controlled by owner which are
+// not in desired.
+//
+// For each of kinds, Prune lists the objects of that kind in the owner's
+// namespace (narrowed further by opts, if given) and deletes those whose
+// controller reference points at owner but which are not themselves in desired.
+// Kinds are given separately from desired so that every object of a kind can be
+// pruned by passing no desired objects of it.  Objects that are not controlled
+// by owner are never deleted.  The scheme is used to map kinds to their list
+// types, and desired objects to their kinds; each desired object must be of one
+// of kinds.  Desired objects without a namespace are taken to be in the
+// namespace being pruned.
+func Prune(ctx context.Context, c Client, scheme *runtime.Scheme, owner runtime.Object,
+	kinds []schema.GroupVersionKind, desired []runtime.Object, opts *ListOptions) error {
+	ownerMeta, err := meta.Accessor(owner)
+	if err != nil {
+		return err
+	}
+
+	listOpts := ListOptions{}
+	if opts != nil {
+		listOpts = *opts
+	}
+	if listOpts.Namespace == "" {
+		listOpts.Namespace = ownerMeta.GetNamespace()
+	}
+
+	// Group the keys of the desired objects by kind
+	keep := map[schema.GroupVersionKind]map[ObjectKey]bool{}
+	for _, gvk := range kinds {
+		keep[gvk] = map[ObjectKey]bool{}
+	}
+	for _, obj := range desired {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return err
+		}
+		if keep[gvk] == nil {
+			return fmt.Errorf("desired object of kind %v is not one of the kinds to prune", gvk)
+		}
+		key, err := ObjectKeyFromObject(obj)
+		if err != nil {
+			return err
+		}
+		// Desired objects are often built without a namespace, but listed
+		// objects always carry theirs
+		if key.Namespace == "" {
+			key.Namespace = listOpts.Namespace
+		}
+		keep[gvk][key] = true
+	}
+
+	for gvk, keys := range keep {
+		list, err := scheme.New(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err != nil {
+			return err
+		}
+		if err := c.List(ctx, &listOpts, list); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			itemMeta, err := meta.Accessor(item)
+			if err != nil {
+				return err
+			}
+			ref := metav1.GetControllerOf(itemMeta)
+			if ref == nil || ref.UID != ownerMeta.GetUID() {
+				continue
+			}
+			if keys[ObjectKey{Namespace: itemMeta.GetNamespace(), Name: itemMeta.GetName()}] {
+				continue
+			}
+			if err := c.Delete(ctx, item); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tsungming/controller-runtime/pkg/client"
+	"github.com/tsungming/controller-runtime/pkg/client/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("Prune", func() {
+	configMapGVK := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	var owner *appsv1.Deployment
+	var cl client.Client
+
+	configMap := func(name string, controller *appsv1.Deployment) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prune"},
+		}
+		if controller != nil {
+			isController := true
+			cm.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       controller.Name,
+				UID:        controller.UID,
+				Controller: &isController,
+			}}
+		}
+		return cm
+	}
+
+	BeforeEach(func() {
+		owner = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "prune", UID: "owner-uid"},
+		}
+		other := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "prune", UID: "other-uid"},
+		}
+		cl = fake.NewFakeClient(
+			configMap("desired", owner),
+			configMap("extra", owner),
+			configMap("foreign", other),
+			configMap("unowned", nil),
+		)
+	})
+
+	configMapNames := func() []string {
+		list := &corev1.ConfigMapList{}
+		Expect(cl.List(context.TODO(), client.InNamespace("prune"), list)).To(Succeed())
+		var names []string
+		for _, cm := range list.Items {
+			names = append(names, cm.Name)
+		}
+		return names
+	}
+
+	It("should delete owned objects that are not desired", func() {
+		err := client.Prune(context.TODO(), cl, kscheme.Scheme, owner, []schema.GroupVersionKind{configMapGVK},
+			[]runtime.Object{configMap("desired", owner)}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMapNames()).To(ConsistOf("desired", "foreign", "unowned"))
+	})
+
+	It("should keep desired objects given without a namespace", func() {
+		desired := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "desired"}}
+		err := client.Prune(context.TODO(), cl, kscheme.Scheme, owner, []schema.GroupVersionKind{configMapGVK},
+			[]runtime.Object{desired}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMapNames()).To(ConsistOf("desired", "foreign", "unowned"))
+	})
+
+	It("should delete every owned object of a kind with no desired objects", func() {
+		err := client.Prune(context.TODO(), cl, kscheme.Scheme, owner, []schema.GroupVersionKind{configMapGVK}, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMapNames()).To(ConsistOf("foreign", "unowned"))
+	})
+
+	It("should not touch kinds that are not given", func() {
+		err := client.Prune(context.TODO(), cl, kscheme.Scheme, owner, nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMapNames()).To(ConsistOf("desired", "extra", "foreign", "unowned"))
+	})
+
+	It("should fail if a desired object is not of one of the kinds", func() {
+		err := client.Prune(context.TODO(), cl, kscheme.Scheme, owner, nil,
+			[]runtime.Object{configMap("desired", owner)}, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(configMapNames()).To(ConsistOf("desired", "extra", "foreign", "unowned"))
+	})
+
+	It("should fail if a kind is not in the scheme", func() {
+		err := client.Prune(context.TODO(), cl, runtime.NewScheme(), owner, []schema.GroupVersionKind{configMapGVK}, nil, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Action is a mutation performed through a RecordingClient.
+type Action struct {
+	// Verb is the kind of mutation: "create", "update", "delete" or "patch".
+	Verb string
+
+	// Subresource is the subresource that was written, e.g. "status", or
+	// empty if the object itself was written.
+	Subresource string
+
+	// Object is a copy of the object taken once the request completed, so it
+	// reflects the content returned by the Server.
+	Object runtime.Object
+
+	// Patch is the patch that was applied, if Verb is "patch".
+	Patch Patch
+
+	// Err is the error returned by the wrapped client, if any.
+	Err error
+
+	// Timestamp is the time at which the request completed.
+	Timestamp time.Time
+}
+
+// RecordingClient is a Client that records every mutation made through it
+// before passing on the result of the wrapped Client.  Reads are passed through
+// and not recorded.  It is useful for asserting what a reconcile did.
+type RecordingClient struct {
+	Client
+
+	mu      sync.Mutex
+	actions []Action
+}
+
+var _ Client = &RecordingClient{}
+
+// NewRecordingClient returns a RecordingClient that wraps c.
+func NewRecordingClient(c Client) *RecordingClient {
+	return &RecordingClient{Client: c}
+}
+
+// Actions returns the recorded mutations in the order they were made.
+func (c *RecordingClient) Actions() []Action {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Action(nil), c.actions...)
+}
+
+// Create implements client.Client
+func (c *RecordingClient) Create(ctx context.Context, obj runtime.Object) error {
+	err := c.Client.Create(ctx, obj)
+	c.record(Action{Verb: "create", Object: obj, Err: err})
+	return err
+}
+
+// Update implements client.Client
+func (c *RecordingClient) Update(ctx context.Context, obj runtime.Object) error {
+	err := c.Client.Update(ctx, obj)
+	c.record(Action{Verb: "update", Object: obj, Err: err})
+	return err
+}
+
+// Delete implements client.Client
+func (c *RecordingClient) Delete(ctx context.Context, obj runtime.Object, opts ...DeleteOptionFunc) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	c.record(Action{Verb: "delete", Object: obj, Err: err})
+	return err
+}
+
+// Patch implements client.Client
+func (c *RecordingClient) Patch(ctx context.Context, obj runtime.Object, patch Patch) error {
+	err := c.Client.Patch(ctx, obj, patch)
+	c.record(Action{Verb: "patch", Object: obj, Patch: patch, Err: err})
+	return err
+}
+
+// Status implements client.StatusClient
+func (c *RecordingClient) Status() StatusWriter {
+	return &recordingStatusWriter{client: c, writer: c.Client.Status()}
+}
+
+// record appends action, replacing its Object with a deep copy.
+func (c *RecordingClient) record(action Action) {
+	action.Object = action.Object.DeepCopyObject()
+	action.Timestamp = time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.actions = append(c.actions, action)
+}
+
+// recordingStatusWriter records status updates on its RecordingClient.
+type recordingStatusWriter struct {
+	client *RecordingClient
+	writer StatusWriter
+}
+
+// Update implements client.StatusWriter
+func (sw *recordingStatusWriter) Update(ctx context.Context, obj runtime.Object) error {
+	err := sw.writer.Update(ctx, obj)
+	sw.client.record(Action{Verb: "update", Subresource: "status", Object: obj, Err: err})
+	return err
+}
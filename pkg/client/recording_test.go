@@ -0,0 +1,95 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tsungming/controller-runtime/pkg/client"
+	"github.com/tsungming/controller-runtime/pkg/client/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("RecordingClient", func() {
+	var cl *client.RecordingClient
+
+	BeforeEach(func() {
+		cl = client.NewRecordingClient(fake.NewFakeClient(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "recording"},
+		}))
+	})
+
+	It("should record mutations in order", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "recording"}}
+		Expect(cl.Create(context.TODO(), cm)).To(Succeed())
+
+		cm.Data = map[string]string{"foo": "bar"}
+		Expect(cl.Update(context.TODO(), cm)).To(Succeed())
+
+		patch := client.ConstantPatch(types.MergePatchType, []byte(`{"data":{"foo":"baz"}}`))
+		Expect(cl.Patch(context.TODO(), cm, patch)).To(Succeed())
+		Expect(cl.Status().Update(context.TODO(), cm)).To(Succeed())
+		Expect(cl.Delete(context.TODO(), cm)).To(Succeed())
+
+		actions := cl.Actions()
+		Expect(actions).To(HaveLen(5))
+		Expect(actions[0].Verb).To(Equal("create"))
+		Expect(actions[1].Verb).To(Equal("update"))
+		Expect(actions[2].Verb).To(Equal("patch"))
+		Expect(actions[2].Patch).To(Equal(patch))
+		Expect(actions[3].Verb).To(Equal("update"))
+		Expect(actions[3].Subresource).To(Equal("status"))
+		Expect(actions[4].Verb).To(Equal("delete"))
+		for i := 1; i < len(actions); i++ {
+			Expect(actions[i].Timestamp).NotTo(BeTemporally("<", actions[i-1].Timestamp))
+		}
+	})
+
+	It("should record deep copies of the objects", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "recording"}}
+		Expect(cl.Create(context.TODO(), cm)).To(Succeed())
+		cm.Data = map[string]string{"foo": "bar"}
+		Expect(cl.Update(context.TODO(), cm)).To(Succeed())
+		cm.Data["foo"] = "changed"
+
+		actions := cl.Actions()
+		Expect(actions[0].Object).NotTo(BeIdenticalTo(cm))
+		Expect(actions[0].Object.(*corev1.ConfigMap).Data).To(BeEmpty())
+		Expect(actions[1].Object.(*corev1.ConfigMap).Data).To(Equal(map[string]string{"foo": "bar"}))
+	})
+
+	It("should record failed mutations with their error", func() {
+		err := cl.Create(context.TODO(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "recording"},
+		})
+		Expect(err).To(HaveOccurred())
+
+		actions := cl.Actions()
+		Expect(actions).To(HaveLen(1))
+		Expect(actions[0].Err).To(Equal(err))
+	})
+
+	It("should not record reads", func() {
+		Expect(cl.Get(context.TODO(), client.ObjectKey{Namespace: "recording", Name: "existing"}, &corev1.ConfigMap{})).To(Succeed())
+		Expect(cl.List(context.TODO(), &client.ListOptions{Namespace: "recording"}, &corev1.ConfigMapList{})).To(Succeed())
+		Expect(cl.Actions()).To(BeEmpty())
+	})
+})
@@ -32,14 +32,23 @@ type DelegatingClient struct {
 	StatusClient
 }
 
+var _ Client = &DelegatingClient{}
+
 // DelegatingReader forms a interface Reader that will cause Get and List
 // requests for unstructured types to use the ClientReader while
 // requests for any other type of object with use the CacheReader.
 type DelegatingReader struct {
 	CacheReader  Reader
 	ClientReader Reader
+
+	// UncachedList causes all List requests to use the ClientReader, so that
+	// Lists always reflect the latest state in the API server.  Get requests
+	// are unaffected.
+	UncachedList bool
 }
 
+var _ Reader = &DelegatingReader{}
+
 // Get retrieves an obj for a given object key from the Kubernetes Cluster.
 func (d *DelegatingReader) Get(ctx context.Context, key ObjectKey, obj runtime.Object) error {
 	_, isUnstructured := obj.(*unstructured.Unstructured)
@@ -52,7 +61,7 @@ func (d *DelegatingReader) Get(ctx context.Context, key ObjectKey, obj runtime.O
 // List retrieves list of objects for a given namespace and list options.
 func (d *DelegatingReader) List(ctx context.Context, opts *ListOptions, list runtime.Object) error {
 	_, isUnstructured := list.(*unstructured.UnstructuredList)
-	if isUnstructured {
+	if isUnstructured || d.UncachedList {
 		return d.ClientReader.List(ctx, opts, list)
 	}
 	return d.CacheReader.List(ctx, opts, list)
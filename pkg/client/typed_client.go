@@ -75,6 +75,25 @@ func (c *typedClient) Delete(_ context.Context, obj runtime.Object, opts ...Dele
 		Error()
 }
 
+// Patch implements client.Client
+func (c *typedClient) Patch(_ context.Context, obj runtime.Object, patch Patch) error {
+	o, err := c.cache.getObjMeta(obj)
+	if err != nil {
+		return err
+	}
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	return o.Patch(patch.Type()).
+		NamespaceIfScoped(o.GetNamespace(), o.isNamespaced()).
+		Resource(o.resource()).
+		Name(o.GetName()).
+		Body(data).
+		Do().
+		Into(obj)
+}
+
 // Get implements client.Client
 func (c *typedClient) Get(_ context.Context, key ObjectKey, obj runtime.Object) error {
 	r, err := c.cache.getResource(obj)
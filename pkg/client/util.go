@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// defaultListPageSize is the number of objects requested per page by ListEach
+// if opts does not set a limit.
+const defaultListPageSize = 500
+
+// Exists returns true if the object identified by key exists, reading it into obj.
+// It returns false and a nil error if the object is not found, and false with the
+// error for any other failure.
+func Exists(ctx context.Context, r Reader, key ObjectKey, obj runtime.Object) (bool, error) {
+	err := r.Get(ctx, key, obj)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// IgnoreNotFound returns nil if err is a NotFound error, and err otherwise.
+// It is useful for deletes and reads where a missing object is not a failure.
+func IgnoreNotFound(err error) error {
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// IgnoreAlreadyExists returns nil if err is an AlreadyExists error, and err
+// otherwise.  It is useful for creating objects which may already exist.
+func IgnoreAlreadyExists(err error) error {
+	if errors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// ListEach lists the objects matching opts page by page and calls fn for each
+// of them, instead of returning every object at once.  list is the list type to
+// read each page into; it is cleared before every page.  If opts.Raw does not set
+// a Limit, pages of defaultListPageSize objects are requested.
+//
+// The objects passed to fn belong to list, so fn must DeepCopy any object it
+// keeps after returning.
+//
+// ListEach stops and returns the error if listing fails or fn returns an error.
+func ListEach(ctx context.Context, r Reader, list runtime.Object, opts *ListOptions, fn func(runtime.Object) error) error {
+	pageOpts := ListOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	raw := metav1.ListOptions{}
+	if pageOpts.Raw != nil {
+		raw = *pageOpts.Raw
+	}
+	if raw.Limit <= 0 {
+		raw.Limit = defaultListPageSize
+	}
+	pageOpts.Raw = &raw
+
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return err
+	}
+	for {
+		// Clear the list first, since decoders may reuse the items of the
+		// previous page and keep fields, including the continue token, which
+		// are not set in the new one.
+		if err := meta.SetList(list, nil); err != nil {
+			return err
+		}
+		listMeta.SetContinue("")
+		if err := r.List(ctx, &pageOpts, list); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		if listMeta.GetContinue() == "" {
+			return nil
+		}
+		raw.Continue = listMeta.GetContinue()
+	}
+}
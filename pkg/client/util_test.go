@@ -0,0 +1,218 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tsungming/controller-runtime/pkg/client"
+	"github.com/tsungming/controller-runtime/pkg/client/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("Exists", func() {
+	var cl client.Client
+
+	BeforeEach(func() {
+		cl = fake.NewFakeClient(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "exists", Namespace: "util"},
+		})
+	})
+
+	It("should return true if the object exists", func() {
+		obj := &corev1.ConfigMap{}
+		exists, err := client.Exists(context.TODO(), cl, client.ObjectKey{Namespace: "util", Name: "exists"}, obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeTrue())
+		Expect(obj.Name).To(Equal("exists"))
+	})
+
+	It("should return false without an error if the object is not found", func() {
+		exists, err := client.Exists(context.TODO(), cl, client.ObjectKey{Namespace: "util", Name: "missing"}, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("should return the error if the object cannot be read", func() {
+		exists, err := client.Exists(context.TODO(), cl, client.ObjectKey{Namespace: "util", Name: "exists"}, &testCRD{})
+		Expect(err).To(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+})
+
+var _ = Describe("IgnoreNotFound", func() {
+	It("should return nil for a NotFound error", func() {
+		err := errors.NewNotFound(corev1.Resource("configmaps"), "missing")
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+	})
+
+	It("should return any other error", func() {
+		err := errors.NewAlreadyExists(corev1.Resource("configmaps"), "existing")
+		Expect(client.IgnoreNotFound(err)).To(Equal(err))
+	})
+
+	It("should return nil for a nil error", func() {
+		Expect(client.IgnoreNotFound(nil)).To(Succeed())
+	})
+})
+
+var _ = Describe("IgnoreAlreadyExists", func() {
+	It("should return nil for an AlreadyExists error", func() {
+		err := errors.NewAlreadyExists(corev1.Resource("configmaps"), "existing")
+		Expect(client.IgnoreAlreadyExists(err)).To(Succeed())
+	})
+
+	It("should return any other error", func() {
+		err := errors.NewNotFound(corev1.Resource("configmaps"), "missing")
+		Expect(client.IgnoreAlreadyExists(err)).To(Equal(err))
+	})
+
+	It("should return nil for a nil error", func() {
+		Expect(client.IgnoreAlreadyExists(nil)).To(Succeed())
+	})
+
+	It("should make repeated creates idempotent", func() {
+		cl := fake.NewFakeClient()
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "once", Namespace: "util"}}
+		Expect(client.IgnoreAlreadyExists(cl.Create(context.TODO(), cm.DeepCopy()))).To(Succeed())
+		Expect(client.IgnoreAlreadyExists(cl.Create(context.TODO(), cm.DeepCopy()))).To(Succeed())
+	})
+})
+
+var _ = Describe("ListEach", func() {
+	var reader *pagedReader
+
+	BeforeEach(func() {
+		reader = &pagedReader{}
+		for i := 0; i < 5; i++ {
+			cm := corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cm-%d", i), Namespace: "util"},
+			}
+			// Only the first page sets optional fields, so that fields leaking
+			// into later pages are noticed.
+			if i < 2 {
+				cm.Labels = map[string]string{"page": "first"}
+				cm.Data = map[string]string{"foo": "bar"}
+			}
+			reader.items = append(reader.items, cm)
+		}
+	})
+
+	It("should call the function for every object across all pages", func() {
+		var names []string
+		err := client.ListEach(context.TODO(), reader, &corev1.ConfigMapList{},
+			&client.ListOptions{Raw: &metav1.ListOptions{Limit: 2}},
+			func(obj runtime.Object) error {
+				names = append(names, obj.(*corev1.ConfigMap).Name)
+				return nil
+			})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"cm-0", "cm-1", "cm-2", "cm-3", "cm-4"}))
+		Expect(reader.pages).To(Equal(3))
+	})
+
+	It("should not carry fields of previous pages into later ones", func() {
+		var seen []*corev1.ConfigMap
+		err := client.ListEach(context.TODO(), reader, &corev1.ConfigMapList{},
+			&client.ListOptions{Raw: &metav1.ListOptions{Limit: 2}},
+			func(obj runtime.Object) error {
+				seen = append(seen, obj.(*corev1.ConfigMap).DeepCopy())
+				return nil
+			})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seen).To(HaveLen(5))
+		for i, cm := range seen {
+			Expect(*cm).To(Equal(reader.items[i]))
+		}
+	})
+
+	It("should request a default page size if no limit is set", func() {
+		err := client.ListEach(context.TODO(), reader, &corev1.ConfigMapList{}, nil,
+			func(runtime.Object) error { return nil })
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reader.limit).To(BeNumerically(">", 0))
+		Expect(reader.pages).To(Equal(1))
+	})
+
+	It("should stop at the first error returned by the function", func() {
+		var count int
+		err := client.ListEach(context.TODO(), reader, &corev1.ConfigMapList{},
+			&client.ListOptions{Raw: &metav1.ListOptions{Limit: 2}},
+			func(obj runtime.Object) error {
+				count++
+				if count == 3 {
+					return fmt.Errorf("stop")
+				}
+				return nil
+			})
+		Expect(err).To(MatchError("stop"))
+		Expect(count).To(Equal(3))
+		Expect(reader.pages).To(Equal(2))
+	})
+})
+
+// pagedReader serves ConfigMapLists in pages according to the Limit and
+// Continue fields of the raw list options.
+type pagedReader struct {
+	items []corev1.ConfigMap
+	pages int
+	limit int64
+}
+
+func (p *pagedReader) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (p *pagedReader) List(ctx context.Context, opts *client.ListOptions, list runtime.Object) error {
+	p.pages++
+	p.limit = opts.Raw.Limit
+	start := 0
+	if opts.Raw.Continue != "" {
+		var err error
+		if start, err = strconv.Atoi(opts.Raw.Continue); err != nil {
+			return err
+		}
+	}
+	end := start + int(opts.Raw.Limit)
+	cont := strconv.Itoa(end)
+	if end >= len(p.items) {
+		end = len(p.items)
+		cont = ""
+	}
+	// Decode the page into list like the typed client does, so that items
+	// already in list are reused rather than replaced.
+	page := &corev1.ConfigMapList{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMapList"},
+		ListMeta: metav1.ListMeta{Continue: cont},
+		Items:    p.items[start:end],
+	}
+	data, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	_, _, err = kscheme.Codecs.UniversalDeserializer().Decode(data, nil, list)
+	return err
+}
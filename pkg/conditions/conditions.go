@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ReadyCondition is the type of the condition set by MarkReconcileError and
+	// MarkReconcileSuccess.
+	ReadyCondition = "Ready"
+
+	// ReconcileErrorReason is the reason of the Ready condition after a failed reconcile.
+	ReconcileErrorReason = "ReconcileError"
+
+	// ReconcileSuccessReason is the reason of the Ready condition after a successful reconcile.
+	ReconcileSuccessReason = "ReconcileSuccess"
+)
+
+// Condition is an observation of some aspect of an object's state, as found
+// in .status.conditions.
+type Condition struct {
+	// Type of the condition, e.g. Ready.
+	Type string `json:"type"`
+
+	// Status of the condition, one of True, False or Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition changed from one
+	// status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a machine readable explanation of the last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable explanation of the last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy returns a copy of the receiver.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// Object is an object with status conditions.  Types embedding a []Condition
+// in their status implement it to be used with this package.
+type Object interface {
+	// GetConditions returns the conditions of the object.
+	GetConditions() []Condition
+
+	// SetConditions replaces the conditions of the object.
+	SetConditions([]Condition)
+}
+
+// Find returns the condition of obj with the given type, or nil if there is none.
+func Find(obj Object, conditionType string) *Condition {
+	for _, c := range obj.GetConditions() {
+		if c.Type == conditionType {
+			return c.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// Set adds condition to obj, replacing any existing condition of the same type.
+// LastTransitionTime is set to now if the status changed, and kept otherwise.
+func Set(obj Object, condition Condition) {
+	conditions := obj.GetConditions()
+	for i := range conditions {
+		if conditions[i].Type != condition.Type {
+			continue
+		}
+		if conditions[i].Status == condition.Status {
+			condition.LastTransitionTime = conditions[i].LastTransitionTime
+		} else {
+			condition.LastTransitionTime = metav1.Now()
+		}
+		conditions[i] = condition
+		obj.SetConditions(conditions)
+		return
+	}
+	condition.LastTransitionTime = metav1.Now()
+	obj.SetConditions(append(conditions, condition))
+}
+
+// MarkReconcileError sets the Ready condition of obj to False with err as the
+// message.  It does nothing if obj does not implement Object or err is nil.
+func MarkReconcileError(obj interface{}, err error) {
+	o, ok := obj.(Object)
+	if !ok || err == nil {
+		return
+	}
+	Set(o, Condition{
+		Type:    ReadyCondition,
+		Status:  corev1.ConditionFalse,
+		Reason:  ReconcileErrorReason,
+		Message: err.Error(),
+	})
+}
+
+// MarkReconcileSuccess sets the Ready condition of obj to True, clearing any
+// error recorded by MarkReconcileError.  It does nothing if obj does not
+// implement Object.
+func MarkReconcileSuccess(obj interface{}) {
+	o, ok := obj.(Object)
+	if !ok {
+		return
+	}
+	Set(o, Condition{
+		Type:   ReadyCondition,
+		Status: corev1.ConditionTrue,
+		Reason: ReconcileSuccessReason,
+	})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tsungming/controller-runtime/pkg/conditions"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("conditions", func() {
+	var obj *fakeCR
+
+	BeforeEach(func() {
+		obj = &fakeCR{}
+	})
+
+	It("should set the Ready condition to False on a reconcile error", func() {
+		conditions.MarkReconcileError(obj, fmt.Errorf("could not create pod"))
+
+		Expect(obj.Status.Conditions).To(HaveLen(1))
+		c := obj.Status.Conditions[0]
+		Expect(c.Type).To(Equal(conditions.ReadyCondition))
+		Expect(c.Status).To(Equal(corev1.ConditionFalse))
+		Expect(c.Reason).To(Equal(conditions.ReconcileErrorReason))
+		Expect(c.Message).To(Equal("could not create pod"))
+		Expect(c.LastTransitionTime.IsZero()).To(BeFalse())
+	})
+
+	It("should clear the error on a successful reconcile", func() {
+		conditions.MarkReconcileError(obj, fmt.Errorf("could not create pod"))
+		conditions.MarkReconcileSuccess(obj)
+
+		Expect(obj.Status.Conditions).To(HaveLen(1))
+		c := conditions.Find(obj, conditions.ReadyCondition)
+		Expect(c).NotTo(BeNil())
+		Expect(c.Status).To(Equal(corev1.ConditionTrue))
+		Expect(c.Reason).To(Equal(conditions.ReconcileSuccessReason))
+		Expect(c.Message).To(BeEmpty())
+	})
+
+	It("should keep the transition time if the status does not change", func() {
+		then := metav1.NewTime(metav1.Now().Add(-time.Hour))
+		obj.Status.Conditions = []conditions.Condition{{
+			Type:               conditions.ReadyCondition,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: then,
+		}}
+		conditions.MarkReconcileError(obj, fmt.Errorf("still failing"))
+
+		c := conditions.Find(obj, conditions.ReadyCondition)
+		Expect(c.LastTransitionTime).To(Equal(then))
+		Expect(c.Message).To(Equal("still failing"))
+	})
+
+	It("should leave other conditions untouched", func() {
+		obj.Status.Conditions = []conditions.Condition{{Type: "Available", Status: corev1.ConditionTrue}}
+		conditions.MarkReconcileSuccess(obj)
+
+		Expect(obj.Status.Conditions).To(HaveLen(2))
+		Expect(conditions.Find(obj, "Available").Status).To(Equal(corev1.ConditionTrue))
+	})
+
+	It("should do nothing for objects without conditions", func() {
+		pod := &corev1.Pod{}
+		conditions.MarkReconcileError(pod, fmt.Errorf("could not create pod"))
+		conditions.MarkReconcileSuccess(pod)
+		Expect(pod).To(Equal(&corev1.Pod{}))
+	})
+})
+
+// fakeCR is a custom resource with status conditions.
+type fakeCR struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	Status fakeCRStatus
+}
+
+type fakeCRStatus struct {
+	Conditions []conditions.Condition
+}
+
+func (f *fakeCR) GetConditions() []conditions.Condition {
+	return f.Status.Conditions
+}
+
+func (f *fakeCR) SetConditions(c []conditions.Condition) {
+	f.Status.Conditions = c
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Middleware wraps a Reconciler to add behavior around each call to Reconcile,
+// such as logging or panic recovery.
+type Middleware func(Reconciler) Reconciler
+
+// Chain composes middlewares into a single Middleware.  The first middleware is
+// the outermost one, so for Chain(a, b)(r) a runs first, then b, then r.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(r Reconciler) Reconciler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			r = middlewares[i](r)
+		}
+		return r
+	}
+}
+
+// WithLogging returns a Middleware that logs the outcome of each reconcile to
+// log: errors are logged as errors, and results at verbosity 1.
+func WithLogging(log logr.Logger) Middleware {
+	return func(r Reconciler) Reconciler {
+		return Func(func(req Request) (Result, error) {
+			start := time.Now()
+			result, err := r.Reconcile(req)
+			kv := []interface{}{"request", req.NamespacedName, "duration", time.Since(start)}
+			if err != nil {
+				log.Error(err, "reconcile failed", kv...)
+			} else {
+				log.V(1).Info("reconcile succeeded", append(kv, "requeue", result.Requeue, "requeueAfter", result.RequeueAfter)...)
+			}
+			return result, err
+		})
+	}
+}
+
+// WithRecover returns a Middleware that turns a panic in Reconcile into an
+// error, so that the request is retried instead of crashing the process.
+func WithRecover() Middleware {
+	return func(r Reconciler) Reconciler {
+		return Func(func(req Request) (result Result, err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					result, err = Result{}, fmt.Errorf("panic reconciling %s: %v", req.NamespacedName, p)
+				}
+			}()
+			return r.Reconcile(req)
+		})
+	}
+}
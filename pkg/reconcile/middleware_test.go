@@ -0,0 +1,112 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile_test
+
+import (
+	"bytes"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tsungming/controller-runtime/pkg/reconcile"
+	logf "github.com/tsungming/controller-runtime/pkg/runtime/log"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Middleware", func() {
+	request := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "foo", Namespace: "bar"},
+	}
+
+	Describe("Chain", func() {
+		It("should run the middlewares in order around the reconciler", func() {
+			var calls []string
+			trace := func(name string) reconcile.Middleware {
+				return func(r reconcile.Reconciler) reconcile.Reconciler {
+					return reconcile.Func(func(req reconcile.Request) (reconcile.Result, error) {
+						calls = append(calls, name+" before")
+						result, err := r.Reconcile(req)
+						calls = append(calls, name+" after")
+						return result, err
+					})
+				}
+			}
+			base := reconcile.Func(func(reconcile.Request) (reconcile.Result, error) {
+				calls = append(calls, "reconcile")
+				return reconcile.Result{Requeue: true}, nil
+			})
+
+			result, err := reconcile.Chain(trace("a"), trace("b"))(base).Reconcile(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{Requeue: true}))
+			Expect(calls).To(Equal([]string{"a before", "b before", "reconcile", "b after", "a after"}))
+		})
+
+		It("should return the reconciler unchanged without middlewares", func() {
+			base := reconcile.Func(func(reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{Requeue: true}, nil
+			})
+			result, err := reconcile.Chain()(base).Reconcile(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{Requeue: true}))
+		})
+	})
+
+	Describe("WithRecover", func() {
+		It("should turn a panic into an error", func() {
+			base := reconcile.Func(func(reconcile.Request) (reconcile.Result, error) {
+				panic("boom")
+			})
+			result, err := reconcile.WithRecover()(base).Reconcile(request)
+			Expect(err).To(MatchError("panic reconciling bar/foo: boom"))
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+
+		It("should pass through results when there is no panic", func() {
+			base := reconcile.Func(func(reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{Requeue: true}, fmt.Errorf("failed")
+			})
+			result, err := reconcile.WithRecover()(base).Reconcile(request)
+			Expect(err).To(MatchError("failed"))
+			Expect(result).To(Equal(reconcile.Result{Requeue: true}))
+		})
+	})
+
+	Describe("WithLogging", func() {
+		It("should log reconcile errors", func() {
+			out := &bytes.Buffer{}
+			base := reconcile.Func(func(reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, fmt.Errorf("failed")
+			})
+			_, err := reconcile.WithLogging(logf.ZapLoggerTo(out, true))(base).Reconcile(request)
+			Expect(err).To(MatchError("failed"))
+			Expect(out.String()).To(ContainSubstring("reconcile failed"))
+			Expect(out.String()).To(ContainSubstring("bar/foo"))
+		})
+
+		It("should log successful reconciles", func() {
+			out := &bytes.Buffer{}
+			base := reconcile.Func(func(reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{Requeue: true}, nil
+			})
+			result, err := reconcile.WithLogging(logf.ZapLoggerTo(out, true))(base).Reconcile(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{Requeue: true}))
+			Expect(out.String()).To(ContainSubstring("reconcile succeeded"))
+		})
+	})
+})
@@ -31,6 +31,26 @@ type Result struct {
 	RequeueAfter time.Duration
 }
 
+// Merge combines the Results of several sub-reconcilers into a single Result which requeues as soon
+// as any of them asked to be requeued.  A Result with Requeue set and no RequeueAfter requeues sooner than
+// any RequeueAfter, otherwise the shortest RequeueAfter is used.
+//
+// Errors from sub-reconcilers should be combined separately, e.g. with
+// k8s.io/apimachinery/pkg/util/errors.NewAggregate, and returned alongside the merged Result.
+func Merge(results ...Result) Result {
+	merged := Result{}
+	for _, r := range results {
+		if r.Requeue && r.RequeueAfter <= 0 {
+			return Result{Requeue: true}
+		}
+		merged.Requeue = merged.Requeue || r.Requeue
+		if r.RequeueAfter > 0 && (merged.RequeueAfter <= 0 || r.RequeueAfter < merged.RequeueAfter) {
+			merged.RequeueAfter = r.RequeueAfter
+		}
+	}
+	return merged
+}
+
 // Request contains the information necessary to reconcile a Kubernetes object.  This includes the
 // information to uniquely identify the object - its Name and Namespace.  It does NOT contain information about
 // any specific Event or the object contents itself.
@@ -90,3 +110,46 @@ var _ Reconciler = Func(nil)
 
 // Reconcile implements Reconciler.
 func (r Func) Reconcile(o Request) (Result, error) { return r(o) }
+
+// TerminalError wraps err to indicate that reconciling the Request cannot succeed until the object
+// it refers to changes - e.g. the object's spec is malformed.  The wrapper is advisory only: nothing
+// in this package acts on it, and callers of Reconcile that want to skip requeueing such errors must
+// check for it with IsTerminalError.  The returned error has a Cause() method returning err, so
+// github.com/pkg/errors.Cause can be used to inspect it.  TerminalError returns nil if err is nil.
+func TerminalError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &terminalError{err: err}
+}
+
+// IsTerminalError returns true if err was created by TerminalError, or wraps such an error
+// through a chain of Cause() methods as used by github.com/pkg/errors.
+func IsTerminalError(err error) bool {
+	for err != nil {
+		if _, ok := err.(*terminalError); ok {
+			return true
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return false
+		}
+		err = cause.Cause()
+	}
+	return false
+}
+
+// terminalError is an error that should not cause the Request to be requeued.
+type terminalError struct {
+	err error
+}
+
+// Error implements error
+func (e *terminalError) Error() string {
+	return "terminal error: " + e.err.Error()
+}
+
+// Cause returns the error wrapped by TerminalError.
+func (e *terminalError) Cause() error {
+	return e.err
+}
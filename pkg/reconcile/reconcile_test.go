@@ -18,6 +18,7 @@ package reconcile_test
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -66,4 +67,66 @@ var _ = Describe("reconcile", func() {
 			Expect(actualErr).To(Equal(err))
 		})
 	})
+
+	Describe("Merge", func() {
+		It("should requeue immediately if any result requests a requeue without a delay.", func() {
+			merged := reconcile.Merge(
+				reconcile.Result{RequeueAfter: 30 * time.Second},
+				reconcile.Result{Requeue: true},
+			)
+			Expect(merged).To(Equal(reconcile.Result{Requeue: true}))
+		})
+
+		It("should requeue after the shortest RequeueAfter.", func() {
+			merged := reconcile.Merge(
+				reconcile.Result{RequeueAfter: 30 * time.Second},
+				reconcile.Result{},
+				reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			)
+			Expect(merged).To(Equal(reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}))
+		})
+
+		It("should not requeue if no result requests it.", func() {
+			Expect(reconcile.Merge(reconcile.Result{}, reconcile.Result{})).To(Equal(reconcile.Result{}))
+			Expect(reconcile.Merge()).To(Equal(reconcile.Result{}))
+		})
+	})
+
+	Describe("TerminalError", func() {
+		It("should be identified as a terminal error.", func() {
+			err := reconcile.TerminalError(fmt.Errorf("invalid spec"))
+			Expect(reconcile.IsTerminalError(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("terminal error: invalid spec"))
+		})
+
+		It("should not identify other errors as terminal errors.", func() {
+			Expect(reconcile.IsTerminalError(fmt.Errorf("transient"))).To(BeFalse())
+			Expect(reconcile.IsTerminalError(nil)).To(BeFalse())
+		})
+
+		It("should return nil for a nil error.", func() {
+			Expect(reconcile.TerminalError(nil)).To(BeNil())
+		})
+
+		It("should expose the wrapped error.", func() {
+			cause := fmt.Errorf("invalid spec")
+			err := reconcile.TerminalError(cause)
+			Expect(err.(interface{ Cause() error }).Cause()).To(BeIdenticalTo(cause))
+		})
+
+		It("should identify terminal errors wrapped with a Cause.", func() {
+			err := &causer{cause: reconcile.TerminalError(fmt.Errorf("invalid spec"))}
+			Expect(reconcile.IsTerminalError(err)).To(BeTrue())
+			Expect(reconcile.IsTerminalError(&causer{cause: fmt.Errorf("transient")})).To(BeFalse())
+		})
+	})
 })
+
+// causer wraps an error the way github.com/pkg/errors does.
+type causer struct {
+	cause error
+}
+
+func (c *causer) Error() string { return "wrapped: " + c.cause.Error() }
+
+func (c *causer) Cause() error { return c.cause }